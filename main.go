@@ -0,0 +1,68 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command eks-node-explorer emits a non-interactive snapshot of cluster
+// node utilization via --output, for use in CI, dashboards, and alerting.
+//
+// The interactive Bubble Tea UI (model.UIModel) isn't wired into this
+// entrypoint yet -- that needs a Style constructor this snapshot doesn't
+// have -- so --output is required for now.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cocoaine/eks-node-explorer/pkg/export"
+	"github.com/cocoaine/eks-node-explorer/pkg/model"
+)
+
+func main() {
+	var (
+		output      string
+		extraLabels string
+		promAddr    string
+	)
+	flag.StringVar(&output, "output", "", `non-interactive output format: "json", "csv", "yaml", or "prom"`)
+	flag.StringVar(&extraLabels, "extra-labels", "", "comma-separated node labels to include as extra columns/fields")
+	flag.StringVar(&promAddr, "prom-addr", "", `address to serve Prometheus metrics on for -output=prom (default ":9090")`)
+	flag.Parse()
+
+	if output == "" {
+		fmt.Fprintln(os.Stderr, "eks-node-explorer: -output is required (json, csv, yaml, or prom); the interactive UI isn't wired into this entrypoint yet")
+		os.Exit(1)
+	}
+
+	var labels []string
+	if extraLabels != "" {
+		labels = strings.Split(extraLabels, ",")
+	}
+
+	cluster := model.NewCluster()
+	exporter, err := export.New(output, labels, func() model.Stats { return cluster.Stats() })
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if pe, ok := exporter.(*export.PrometheusExporter); ok {
+		pe.Addr = promAddr
+	}
+
+	if err := exporter.Export(os.Stdout, cluster.Stats()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/cocoaine/eks-node-explorer/pkg/model"
+)
+
+// CSVExporter serializes one row per node, with an allocatable and a used
+// column per resource found across the cluster plus any extra labels.
+type CSVExporter struct {
+	ExtraLabels []string
+}
+
+func (e *CSVExporter) Export(w io.Writer, stats model.Stats) error {
+	return writeCSV(w, stats.Snapshot(e.ExtraLabels), e.ExtraLabels)
+}
+
+// writeCSV renders snapshot as CSV, one row per node. It's split out from
+// Export so the rendering logic can be unit tested against a hand-built
+// ClusterSnapshot, without a live Cluster to produce one.
+func writeCSV(w io.Writer, snapshot model.ClusterSnapshot, extraLabels []string) error {
+	resourceSet := map[string]struct{}{}
+	for _, n := range snapshot.Nodes {
+		for res := range n.Allocatable {
+			resourceSet[res] = struct{}{}
+		}
+	}
+	resources := make([]string, 0, len(resourceSet))
+	for res := range resourceSet {
+		resources = append(resources, res)
+	}
+	sort.Strings(resources)
+
+	header := []string{"name", "instanceType", "instanceId", "capacityType", "priceHourly", "numPods", "ready", "cordoned", "deleting"}
+	for _, res := range resources {
+		header = append(header, res+"Allocatable", res+"Used")
+	}
+	header = append(header, extraLabels...)
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, n := range snapshot.Nodes {
+		row := []string{
+			n.Name,
+			n.InstanceType,
+			n.InstanceID,
+			n.CapacityType,
+			fmt.Sprintf("%0.4f", n.PriceHourly),
+			fmt.Sprintf("%d", n.NumPods),
+			fmt.Sprintf("%t", n.Ready),
+			fmt.Sprintf("%t", n.Cordoned),
+			fmt.Sprintf("%t", n.Deleting),
+		}
+		for _, res := range resources {
+			row = append(row, n.Allocatable[res], n.Used[res])
+		}
+		for _, label := range extraLabels {
+			row = append(row, n.Labels[label])
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,45 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package export implements model.Exporter for the non-interactive
+// --output modes: JSON, CSV, YAML, and a Prometheus exposition endpoint.
+// The top-level main command registers -output and calls New to choose
+// between them.
+package export
+
+import (
+	"fmt"
+
+	"github.com/cocoaine/eks-node-explorer/pkg/model"
+)
+
+// New returns the Exporter registered for format, or an error if format
+// isn't one of "json", "csv", "yaml", or "prom". statsFunc supplies a fresh
+// Stats snapshot on demand; only the "prom" exporter uses it, to recompute
+// its gauges on every scrape instead of once at startup. It may be nil for
+// the other formats, which export a single Stats value and exit.
+func New(format string, extraLabels []string, statsFunc func() model.Stats) (model.Exporter, error) {
+	switch format {
+	case "json":
+		return &JSONExporter{ExtraLabels: extraLabels}, nil
+	case "csv":
+		return &CSVExporter{ExtraLabels: extraLabels}, nil
+	case "yaml":
+		return &YAMLExporter{ExtraLabels: extraLabels}, nil
+	case "prom":
+		return &PrometheusExporter{ExtraLabels: extraLabels, StatsFunc: statsFunc}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format %q", format)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/cocoaine/eks-node-explorer/pkg/model"
+)
+
+func testSnapshot() model.ClusterSnapshot {
+	return model.ClusterSnapshot{
+		NumNodes:         1,
+		TotalPods:        3,
+		BoundPodCount:    3,
+		PodsByPhase:      map[string]int{"Running": 3},
+		TotalPriceHourly: 0.096,
+		Nodes: []model.NodeSnapshot{
+			{
+				Name:         "ip-10-0-1-23.ec2.internal",
+				InstanceType: "m5.large",
+				InstanceID:   "i-0123456789abcdef0",
+				CapacityType: "on-demand",
+				PriceHourly:  0.096,
+				NumPods:      3,
+				Ready:        true,
+				Allocatable:  map[string]string{"cpu": "2", "memory": "8Gi"},
+				Used:         map[string]string{"cpu": "500m", "memory": "2Gi"},
+				Labels:       map[string]string{"zone": "us-west-2a"},
+			},
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, testSnapshot(), []string{"zone"}); err != nil {
+		t.Fatalf("writeCSV: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing written CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2 (header + 1 row)", len(records))
+	}
+
+	header, row := records[0], records[1]
+	wantHeader := []string{"name", "instanceType", "instanceId", "capacityType", "priceHourly", "numPods", "ready", "cordoned", "deleting", "cpuAllocatable", "cpuUsed", "memoryAllocatable", "memoryUsed", "zone"}
+	if strings.Join(header, ",") != strings.Join(wantHeader, ",") {
+		t.Fatalf("header = %v, want %v", header, wantHeader)
+	}
+
+	col := func(name string) string {
+		for i, h := range header {
+			if h == name {
+				return row[i]
+			}
+		}
+		t.Fatalf("no %q column in header", name)
+		return ""
+	}
+	if col("name") != "ip-10-0-1-23.ec2.internal" {
+		t.Errorf("name column = %q", col("name"))
+	}
+	if col("cpuAllocatable") != "2" || col("cpuUsed") != "500m" {
+		t.Errorf("cpu columns = %q/%q, want 2/500m", col("cpuAllocatable"), col("cpuUsed"))
+	}
+	if col("zone") != "us-west-2a" {
+		t.Errorf("zone column = %q, want us-west-2a", col("zone"))
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, testSnapshot()); err != nil {
+		t.Fatalf("writeJSON: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"name": "ip-10-0-1-23.ec2.internal"`) {
+		t.Errorf("encoded JSON missing node name:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), `"zone": "us-west-2a"`) {
+		t.Errorf("encoded JSON missing extra label:\n%s", buf.String())
+	}
+}
+
+func TestWriteYAML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeYAML(&buf, testSnapshot()); err != nil {
+		t.Fatalf("writeYAML: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "instanceType: m5.large") {
+		t.Errorf("encoded YAML missing instanceType:\n%s", out)
+	}
+	if !strings.Contains(out, "numNodes: 1") {
+		t.Errorf("encoded YAML missing numNodes:\n%s", out)
+	}
+}
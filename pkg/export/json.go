@@ -0,0 +1,40 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/cocoaine/eks-node-explorer/pkg/model"
+)
+
+// JSONExporter serializes a ClusterSnapshot as indented JSON.
+type JSONExporter struct {
+	ExtraLabels []string
+}
+
+func (e *JSONExporter) Export(w io.Writer, stats model.Stats) error {
+	return writeJSON(w, stats.Snapshot(e.ExtraLabels))
+}
+
+// writeJSON renders snapshot as indented JSON. It's split out from Export
+// so the rendering logic can be unit tested against a hand-built
+// ClusterSnapshot, without a live Cluster to produce one.
+func writeJSON(w io.Writer, snapshot model.ClusterSnapshot) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snapshot)
+}
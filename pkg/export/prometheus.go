@@ -0,0 +1,139 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/cocoaine/eks-node-explorer/pkg/model"
+)
+
+// PrometheusExporter serves the cluster's resource gauges on Addr for
+// Prometheus/promtool to scrape, labeled by node name, instance type,
+// capacity type, and any configured ExtraLabels. If StatsFunc is set, the
+// gauges are recomputed from it on every scrape so values track the live
+// cluster instead of freezing at the Stats passed to Export.
+type PrometheusExporter struct {
+	Addr        string
+	ExtraLabels []string
+	StatsFunc   func() model.Stats
+
+	registry        *prometheus.Registry
+	nodeAllocatable *prometheus.GaugeVec
+	nodeUsed        *prometheus.GaugeVec
+	nodePriceHourly *prometheus.GaugeVec
+	podsByPhase     *prometheus.GaugeVec
+}
+
+func (e *PrometheusExporter) init() {
+	if e.registry != nil {
+		return
+	}
+
+	nodeLabels := append([]string{"node", "instance_type", "capacity_type", "resource"}, e.ExtraLabels...)
+	priceLabels := append([]string{"node", "instance_type", "capacity_type"}, e.ExtraLabels...)
+
+	e.nodeAllocatable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "node_allocatable",
+		Help: "Allocatable resource quantity per node.",
+	}, nodeLabels)
+	e.nodeUsed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "node_used",
+		Help: "Used resource quantity per node.",
+	}, nodeLabels)
+	e.nodePriceHourly = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "node_price_hourly",
+		Help: "Hourly price for the node's instance type.",
+	}, priceLabels)
+	e.podsByPhase = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pods_by_phase",
+		Help: "Number of pods in each phase across the cluster.",
+	}, []string{"phase"})
+
+	e.registry = prometheus.NewRegistry()
+	e.registry.MustRegister(e.nodeAllocatable, e.nodeUsed, e.nodePriceHourly, e.podsByPhase)
+}
+
+// Export serves the gauges on Addr (default ":9090") until the process
+// exits or the server fails to start. stats seeds the gauges; if StatsFunc
+// is set, every scrape (the first included) recomputes the gauges from it
+// first, so values track the live cluster rather than staying frozen at the
+// moment Export was called.
+func (e *PrometheusExporter) Export(w io.Writer, stats model.Stats) error {
+	e.init()
+	e.update(stats)
+
+	addr := e.Addr
+	if addr == "" {
+		addr = ":9090"
+	}
+	fmt.Fprintf(w, "serving prometheus metrics on %s/metrics\n", addr)
+
+	metrics := promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{})
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if e.StatsFunc != nil {
+			e.update(e.StatsFunc())
+		}
+		metrics.ServeHTTP(rw, r)
+	}))
+	return http.ListenAndServe(addr, mux)
+}
+
+func (e *PrometheusExporter) update(stats model.Stats) {
+	// Reset before repopulating so a node that's gone by this scrape (scaled
+	// down, removed) stops reporting its last-known values forever instead
+	// of just disappearing from stats.Nodes.
+	e.nodeAllocatable.Reset()
+	e.nodeUsed.Reset()
+	e.nodePriceHourly.Reset()
+	e.podsByPhase.Reset()
+
+	for _, n := range stats.Nodes {
+		extra := e.extraLabelValues(n)
+		allocatable, used := n.Allocatable(), n.Used()
+
+		for res, qty := range allocatable {
+			e.nodeAllocatable.WithLabelValues(append([]string{n.Name(), n.InstanceType(), n.CapacityType(), string(res)}, extra...)...).Set(qty.AsApproximateFloat64())
+		}
+		for res, qty := range used {
+			e.nodeUsed.WithLabelValues(append([]string{n.Name(), n.InstanceType(), n.CapacityType(), string(res)}, extra...)...).Set(qty.AsApproximateFloat64())
+		}
+		if n.HasPrice() {
+			e.nodePriceHourly.WithLabelValues(append([]string{n.Name(), n.InstanceType(), n.CapacityType()}, extra...)...).Set(n.Price)
+		}
+	}
+
+	for phase, count := range stats.PodsByPhase {
+		e.podsByPhase.WithLabelValues(string(phase)).Set(float64(count))
+	}
+}
+
+func (e *PrometheusExporter) extraLabelValues(n *model.Node) []string {
+	values := make([]string, 0, len(e.ExtraLabels))
+	for _, label := range e.ExtraLabels {
+		value, ok := n.Labels()[label]
+		if !ok {
+			value = n.ComputeLabel(label)
+		}
+		values = append(values, value)
+	}
+	return values
+}
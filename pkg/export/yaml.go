@@ -0,0 +1,44 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"io"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/cocoaine/eks-node-explorer/pkg/model"
+)
+
+// YAMLExporter serializes a ClusterSnapshot as YAML.
+type YAMLExporter struct {
+	ExtraLabels []string
+}
+
+func (e *YAMLExporter) Export(w io.Writer, stats model.Stats) error {
+	return writeYAML(w, stats.Snapshot(e.ExtraLabels))
+}
+
+// writeYAML renders snapshot as YAML. It's split out from Export so the
+// rendering logic can be unit tested against a hand-built ClusterSnapshot,
+// without a live Cluster to produce one.
+func writeYAML(w io.Writer, snapshot model.ClusterSnapshot) error {
+	out, err := yaml.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
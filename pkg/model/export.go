@@ -0,0 +1,137 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "io"
+
+// Exporter serializes a point-in-time snapshot of cluster Stats to w in a
+// non-interactive output format (JSON, CSV, YAML, Prometheus exposition,
+// etc.) as an alternative to the interactive Bubble Tea UI. Implementations
+// live in pkg/export.
+type Exporter interface {
+	Export(w io.Writer, stats Stats) error
+}
+
+// NodeSnapshot is the serialization-friendly view of a Node used by Exporter
+// implementations.
+type NodeSnapshot struct {
+	Name         string            `json:"name"`
+	InstanceType string            `json:"instanceType"`
+	InstanceID   string            `json:"instanceId"`
+	CapacityType string            `json:"capacityType,omitempty"`
+	PriceHourly  float64           `json:"priceHourly,omitempty"`
+	NumPods      int               `json:"numPods"`
+	Ready        bool              `json:"ready"`
+	Cordoned     bool              `json:"cordoned"`
+	Deleting     bool              `json:"deleting"`
+	Allocatable  map[string]string `json:"allocatable"`
+	Used         map[string]string `json:"used"`
+	Labels       map[string]string `json:"labels,omitempty"`
+}
+
+// ClusterSnapshot is the serialization-friendly view of Stats used by
+// Exporter implementations.
+type ClusterSnapshot struct {
+	NumNodes         int            `json:"numNodes"`
+	TotalPods        int            `json:"totalPods"`
+	BoundPodCount    int            `json:"boundPodCount"`
+	PodsByPhase      map[string]int `json:"podsByPhase"`
+	TotalPriceHourly float64        `json:"totalPriceHourly"`
+	Nodes            []NodeSnapshot `json:"nodes"`
+}
+
+// CapacityType returns the node's capacity type (on-demand, spot, or
+// fargate), or "" if none of them apply.
+func (n *Node) CapacityType() string {
+	switch {
+	case n.IsOnDemand():
+		return "on-demand"
+	case n.IsSpot():
+		return "spot"
+	case n.IsFargate():
+		return "fargate"
+	default:
+		return ""
+	}
+}
+
+// Labels returns the node's Kubernetes labels.
+func (n *Node) Labels() map[string]string {
+	return n.node.Labels
+}
+
+// Snapshot returns a serialization-friendly snapshot of n, resolving
+// extraLabels the same way writeNodeInfo does (falling back to
+// ComputeLabel for computed labels).
+func (n *Node) Snapshot(extraLabels []string) NodeSnapshot {
+	allocatable, used := n.Allocatable(), n.Used()
+
+	snap := NodeSnapshot{
+		Name:         n.Name(),
+		InstanceType: n.InstanceType(),
+		InstanceID:   n.InstanceID(),
+		CapacityType: n.CapacityType(),
+		NumPods:      n.NumPods(),
+		Ready:        n.Ready(),
+		Cordoned:     n.Cordoned(),
+		Deleting:     n.Deleting(),
+		Allocatable:  map[string]string{},
+		Used:         map[string]string{},
+	}
+	if n.HasPrice() {
+		snap.PriceHourly = n.Price
+	}
+	for res, qty := range allocatable {
+		snap.Allocatable[string(res)] = qty.String()
+	}
+	for res, qty := range used {
+		snap.Used[string(res)] = qty.String()
+	}
+
+	if len(extraLabels) > 0 {
+		snap.Labels = map[string]string{}
+		for _, label := range extraLabels {
+			value, ok := n.node.Labels[label]
+			if !ok {
+				value = n.ComputeLabel(label)
+			}
+			snap.Labels[label] = value
+		}
+	}
+
+	return snap
+}
+
+// Snapshot returns a serialization-friendly snapshot of the cluster Stats.
+func (s Stats) Snapshot(extraLabels []string) ClusterSnapshot {
+	nodes := make([]NodeSnapshot, 0, len(s.Nodes))
+	for _, n := range s.Nodes {
+		nodes = append(nodes, n.Snapshot(extraLabels))
+	}
+
+	podsByPhase := make(map[string]int, len(s.PodsByPhase))
+	for phase, count := range s.PodsByPhase {
+		podsByPhase[string(phase)] = count
+	}
+
+	return ClusterSnapshot{
+		NumNodes:         s.NumNodes,
+		TotalPods:        s.TotalPods,
+		BoundPodCount:    s.BoundPodCount,
+		PodsByPhase:      podsByPhase,
+		TotalPriceHourly: s.TotalPrice,
+		Nodes:            nodes,
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "testing"
+
+func TestFuzzyScoreMatch(t *testing.T) {
+	cases := []struct {
+		name   string
+		query  string
+		target string
+		want   bool
+	}{
+		{"empty query matches anything", "", "ip-10-0-1-23.ec2.internal", true},
+		{"ordered subsequence", "ip123", "ip-10-0-1-23.ec2.internal", true},
+		{"out of order subsequence doesn't match", "321pi", "ip-10-0-1-23.ec2.internal", false},
+		{"unrelated query doesn't match", "xyz", "ip-10-0-1-23.ec2.internal", false},
+		{"case insensitive", "IP-10", "ip-10-0-1-23.ec2.internal", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, ok := fuzzyScore(tc.query, tc.target)
+			if ok != tc.want {
+				t.Errorf("fuzzyScore(%q, %q) matched = %v, want %v", tc.query, tc.target, ok, tc.want)
+			}
+		})
+	}
+}
+
+func TestFuzzyScoreRanking(t *testing.T) {
+	// A substring match should outrank a scattered subsequence match, and a
+	// consecutive/word-boundary match should outrank one that isn't.
+	substring, ok := fuzzyScore("node", "my-node-1")
+	if !ok {
+		t.Fatalf("expected substring match")
+	}
+	scattered, ok := fuzzyScore("node", "n-o-d-e-m")
+	if !ok {
+		t.Fatalf("expected scattered subsequence match")
+	}
+	if substring <= scattered {
+		t.Errorf("substring score %d should outrank scattered score %d", substring, scattered)
+	}
+
+	boundary, ok := fuzzyScore("node", "eks-node-1")
+	if !ok {
+		t.Fatalf("expected word-boundary match")
+	}
+	midword, ok := fuzzyScore("node", "anodeb")
+	if !ok {
+		t.Fatalf("expected mid-word match")
+	}
+	if boundary <= midword {
+		t.Errorf("word-boundary score %d should outrank mid-word score %d", boundary, midword)
+	}
+}
+
+func TestScoreSubsequenceRewardsConsecutiveRuns(t *testing.T) {
+	// Neither target starts a match at a word boundary, isolating the
+	// consecutive-run bonus from the boundary bonus.
+	consecutive, ok := scoreSubsequence("bcd", "xbcdxx")
+	if !ok {
+		t.Fatalf("expected match")
+	}
+	scattered, ok := scoreSubsequence("bcd", "xbxcxdxx")
+	if !ok {
+		t.Fatalf("expected match")
+	}
+	if consecutive <= scattered {
+		t.Errorf("consecutive run score %d should exceed scattered score %d", consecutive, scattered)
+	}
+}
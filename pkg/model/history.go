@@ -0,0 +1,162 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// DefaultHistoryRetention is the number of samples kept per node per
+// resource, absent any other configuration (60 samples at the default 10s
+// sample interval is 10 minutes of history).
+const DefaultHistoryRetention = 60
+
+// DefaultSampleInterval is how often the Sampler records a new snapshot.
+const DefaultSampleInterval = 10 * time.Second
+
+// HistorySample is a single point-in-time utilization snapshot for one
+// resource.
+type HistorySample struct {
+	Time  time.Time
+	Ratio float64 // used / allocatable, in [0, 1]
+}
+
+// NodeHistory is a fixed-size ring buffer of HistorySamples per resource,
+// recorded by a Sampler and rendered as a sparkline/chart by UIModel.
+type NodeHistory struct {
+	mu        sync.Mutex
+	retention int
+	samples   map[v1.ResourceName][]HistorySample
+}
+
+// NewNodeHistory returns a NodeHistory that retains at most retention
+// samples per resource.
+func NewNodeHistory(retention int) *NodeHistory {
+	if retention <= 0 {
+		retention = DefaultHistoryRetention
+	}
+	return &NodeHistory{
+		retention: retention,
+		samples:   map[v1.ResourceName][]HistorySample{},
+	}
+}
+
+// Record appends a utilization sample for every resource in allocatable,
+// dropping the oldest sample once retention is exceeded.
+func (h *NodeHistory) Record(t time.Time, used, allocatable v1.ResourceList) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for res, allocatableQty := range allocatable {
+		ratio := 0.0
+		if total := allocatableQty.AsApproximateFloat64(); total != 0 {
+			ratio = used[res].AsApproximateFloat64() / total
+		}
+
+		samples := append(h.samples[res], HistorySample{Time: t, Ratio: ratio})
+		if len(samples) > h.retention {
+			samples = samples[len(samples)-h.retention:]
+		}
+		h.samples[res] = samples
+	}
+}
+
+// Samples returns the retained samples for res, oldest first.
+func (h *NodeHistory) Samples(res v1.ResourceName) []HistorySample {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]HistorySample(nil), h.samples[res]...)
+}
+
+// Sampler periodically records every node's resource utilization into its
+// NodeHistory, so the node list can render sparklines without a metrics
+// backend. A Sampler owns its histories, keyed by node name rather than
+// *Node identity: two Samplers (e.g. in separate tests, or a future
+// multi-cluster UIModel) never share or clobber each other's history, and
+// nothing has to assume Cluster.Stats() returns the same *Node across
+// calls.
+type Sampler struct {
+	mu        sync.Mutex
+	histories map[string]*NodeHistory
+}
+
+// History returns name's NodeHistory, creating an empty one (with
+// DefaultHistoryRetention) on first access.
+func (s *Sampler) History(name string) *NodeHistory {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.histories == nil {
+		s.histories = map[string]*NodeHistory{}
+	}
+	h, ok := s.histories[name]
+	if !ok {
+		h = NewNodeHistory(DefaultHistoryRetention)
+		s.histories[name] = h
+	}
+	return h
+}
+
+// Sample records one utilization snapshot per node in stats, as observed
+// at t, and evicts the NodeHistory of any node that's no longer part of
+// the cluster (scaled down, consolidated, etc.) so histories doesn't grow
+// without bound over the process lifetime.
+func (s *Sampler) Sample(t time.Time, stats Stats) {
+	live := make(map[string]struct{}, len(stats.Nodes))
+	for _, n := range stats.Nodes {
+		s.History(n.Name()).Record(t, n.Used(), n.Allocatable())
+		live[n.Name()] = struct{}{}
+	}
+	s.pruneHistories(live)
+}
+
+// pruneHistories removes every history entry whose node name isn't in live.
+func (s *Sampler) pruneHistories(live map[string]struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name := range s.histories {
+		if _, ok := live[name]; !ok {
+			delete(s.histories, name)
+		}
+	}
+}
+
+// sparkTicks are the unicode block heights used to render a sparkline,
+// lowest to highest.
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders samples as a compact unicode bar chart, one tick per
+// sample, oldest first. It returns "" for no samples.
+func Sparkline(samples []HistorySample) string {
+	if len(samples) == 0 {
+		return ""
+	}
+
+	ticks := make([]rune, len(samples))
+	for i, s := range samples {
+		idx := int(s.Ratio * float64(len(sparkTicks)-1))
+		if idx < 0 {
+			idx = 0
+		} else if idx >= len(sparkTicks) {
+			idx = len(sparkTicks) - 1
+		}
+		ticks[i] = sparkTicks[idx]
+	}
+	return string(ticks)
+}
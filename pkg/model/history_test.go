@@ -0,0 +1,127 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func quantity(q string) resource.Quantity {
+	return resource.MustParse(q)
+}
+
+func TestNodeHistoryRecordComputesRatio(t *testing.T) {
+	h := NewNodeHistory(DefaultHistoryRetention)
+	now := time.Unix(0, 0)
+
+	h.Record(now, v1.ResourceList{v1.ResourceCPU: quantity("500m")}, v1.ResourceList{v1.ResourceCPU: quantity("1")})
+
+	samples := h.Samples(v1.ResourceCPU)
+	if len(samples) != 1 {
+		t.Fatalf("got %d samples, want 1", len(samples))
+	}
+	if samples[0].Ratio != 0.5 {
+		t.Errorf("ratio = %v, want 0.5", samples[0].Ratio)
+	}
+	if !samples[0].Time.Equal(now) {
+		t.Errorf("time = %v, want %v", samples[0].Time, now)
+	}
+}
+
+func TestNodeHistoryRecordZeroAllocatable(t *testing.T) {
+	h := NewNodeHistory(DefaultHistoryRetention)
+	h.Record(time.Unix(0, 0), v1.ResourceList{v1.ResourceCPU: quantity("0")}, v1.ResourceList{v1.ResourceCPU: quantity("0")})
+
+	samples := h.Samples(v1.ResourceCPU)
+	if len(samples) != 1 || samples[0].Ratio != 0 {
+		t.Fatalf("samples = %+v, want a single 0-ratio sample", samples)
+	}
+}
+
+func TestNodeHistoryRecordEvictsOldestPastRetention(t *testing.T) {
+	h := NewNodeHistory(2)
+	base := time.Unix(0, 0)
+	for i := 0; i < 3; i++ {
+		t := base.Add(time.Duration(i) * time.Second)
+		h.Record(t, v1.ResourceList{v1.ResourceCPU: quantity("1")}, v1.ResourceList{v1.ResourceCPU: quantity("1")})
+	}
+
+	samples := h.Samples(v1.ResourceCPU)
+	if len(samples) != 2 {
+		t.Fatalf("got %d samples, want 2 (retention cap)", len(samples))
+	}
+	if !samples[0].Time.Equal(base.Add(time.Second)) {
+		t.Errorf("oldest retained sample = %v, want the second recorded sample", samples[0].Time)
+	}
+}
+
+func TestSparklineEmpty(t *testing.T) {
+	if got := Sparkline(nil); got != "" {
+		t.Errorf("Sparkline(nil) = %q, want empty string", got)
+	}
+}
+
+func TestSparklineMapsRatioRange(t *testing.T) {
+	samples := []HistorySample{{Ratio: 0}, {Ratio: 0.5}, {Ratio: 1}}
+	got := []rune(Sparkline(samples))
+	if len(got) != 3 {
+		t.Fatalf("got %d ticks, want 3", len(got))
+	}
+	if got[0] != sparkTicks[0] {
+		t.Errorf("tick for ratio 0 = %q, want %q", got[0], sparkTicks[0])
+	}
+	if got[2] != sparkTicks[len(sparkTicks)-1] {
+		t.Errorf("tick for ratio 1 = %q, want %q", got[2], sparkTicks[len(sparkTicks)-1])
+	}
+}
+
+func TestSparklineClampsOutOfRangeRatio(t *testing.T) {
+	samples := []HistorySample{{Ratio: -1}, {Ratio: 5}}
+	got := []rune(Sparkline(samples))
+	if got[0] != sparkTicks[0] {
+		t.Errorf("tick for negative ratio = %q, want %q", got[0], sparkTicks[0])
+	}
+	if got[1] != sparkTicks[len(sparkTicks)-1] {
+		t.Errorf("tick for >1 ratio = %q, want %q", got[1], sparkTicks[len(sparkTicks)-1])
+	}
+}
+
+func TestSamplerPruneHistoriesRemovesDeadNodes(t *testing.T) {
+	s := &Sampler{}
+	s.History("live")
+	s.History("dead")
+
+	s.pruneHistories(map[string]struct{}{"live": {}})
+
+	if _, ok := s.histories["live"]; !ok {
+		t.Error("pruneHistories removed a live node's history")
+	}
+	if _, ok := s.histories["dead"]; ok {
+		t.Error("pruneHistories did not remove a dead node's history")
+	}
+}
+
+func TestSamplerHistoryIsPerInstance(t *testing.T) {
+	a, b := &Sampler{}, &Sampler{}
+	a.History("node-1").Record(time.Unix(0, 0), v1.ResourceList{v1.ResourceCPU: quantity("1")}, v1.ResourceList{v1.ResourceCPU: quantity("1")})
+
+	if got := len(b.History("node-1").Samples(v1.ResourceCPU)); got != 0 {
+		t.Errorf("second Sampler saw %d samples for node-1, want 0 (histories must not be shared across instances)", got)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// PodInfo is the per-pod view shown in a node's drill-down pane.
+type PodInfo struct {
+	Name      string
+	Namespace string
+	Phase     v1.PodPhase
+	Owner     string
+	QoSClass  v1.PodQOSClass
+	Requests  v1.ResourceList
+	Usage     v1.ResourceList
+	Age       time.Duration
+}
+
+// Pods returns per-pod resource and status info for n, in the order the
+// cluster watcher observed them.
+func (n *Node) Pods() []PodInfo {
+	infos := make([]PodInfo, 0, len(n.pods))
+	for _, p := range n.pods {
+		infos = append(infos, PodInfo{
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			Phase:     p.Status.Phase,
+			Owner:     podOwnerKind(p),
+			QoSClass:  p.Status.QOSClass,
+			Requests:  podRequests(p),
+			Usage:     n.podUsage[string(p.UID)],
+			Age:       time.Since(p.CreationTimestamp.Time),
+		})
+	}
+	return infos
+}
+
+// podOwnerKind returns the Kind of p's controlling owner reference, or "-"
+// if p has none.
+func podOwnerKind(p *v1.Pod) string {
+	for _, ref := range p.OwnerReferences {
+		if ref.Controller != nil && *ref.Controller {
+			return ref.Kind
+		}
+	}
+	return "-"
+}
+
+// podRequests sums resource requests across all of p's containers.
+func podRequests(p *v1.Pod) v1.ResourceList {
+	total := v1.ResourceList{}
+	for _, c := range p.Spec.Containers {
+		for res, qty := range c.Resources.Requests {
+			sum := total[res]
+			sum.Add(qty)
+			total[res] = sum
+		}
+	}
+	return total
+}
@@ -19,7 +19,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"sort"
 	"strings"
 	"time"
@@ -28,6 +27,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/paginator"
 	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/facette/natsort"
@@ -36,6 +36,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
 
+	"github.com/cocoaine/eks-node-explorer/pkg/nodeexec"
 	"github.com/cocoaine/eks-node-explorer/pkg/text"
 
 	clipboard "golang.design/x/clipboard"
@@ -55,10 +56,16 @@ var (
 type execFinishedMsg struct{ err error }
 
 type KeyMap struct {
-	Move  key.Binding
-	Page  key.Binding
-	Quit  key.Binding
-	Enter key.Binding
+	Move     key.Binding
+	Page     key.Binding
+	Quit     key.Binding
+	Enter    key.Binding
+	Filter   key.Binding
+	Detail   key.Binding
+	Back     key.Binding
+	ExecMode key.Binding
+	Chart    key.Binding
+	NodeCopy key.Binding
 }
 
 var keys = KeyMap{
@@ -78,23 +85,57 @@ var keys = KeyMap{
 		key.WithKeys("q"),
 		key.WithHelp("q", "quit"),
 	),
+	Filter: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "filter"),
+	),
+	Detail: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "pod detail"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back"),
+	),
+	ExecMode: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "cycle exec mode"),
+	),
+	Chart: key.NewBinding(
+		key.WithKeys("g"),
+		key.WithHelp("g", "utilization chart"),
+	),
+	NodeCopy: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "copy/exec node"),
+	),
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view. It's part
 // of the key.Map interface.
 func (k KeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Move, k.Page, k.Enter, k.Quit}
+	return []key.Binding{k.Move, k.Page, k.Enter, k.Filter, k.Detail, k.Chart, k.Back, k.ExecMode, k.NodeCopy, k.Quit}
 }
 
 // FullHelp returns keybindings for the expanded help view. It's part of the
 // key.Map interface.
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Move, k.Page, k.Enter, k.Quit}, // first column
-		{},                                // second column
+		{k.Move, k.Page, k.Enter, k.Filter, k.Detail, k.Chart, k.Back, k.ExecMode, k.NodeCopy, k.Quit}, // first column
+		{}, // second column
 	}
 }
 
+// viewMode selects between the node list, a node's pod drill-down, and a
+// node's utilization chart.
+type viewMode int
+
+const (
+	viewModeList viewMode = iota
+	viewModeDetail
+	viewModeChart
+)
+
 type UIModel struct {
 	progress       progress.Model
 	cluster        *Cluster
@@ -109,8 +150,18 @@ type UIModel struct {
 	start          int
 	end            int
 	err            error
+	execErr        error
 	copyInstanceID bool
-	nodeExec       string
+	execConfig     nodeexec.Config
+	filterInput    textinput.Model
+	filtering      bool
+	viewMode       viewMode
+	podPaginator   paginator.Model
+	podCurrent     int
+	detailNode     *Node
+	chartNode      *Node
+	sampler        Sampler
+	sampling       bool
 }
 
 func (u *UIModel) Stats() Stats {
@@ -119,42 +170,104 @@ func (u *UIModel) Stats() Stats {
 		return u.nodeSorter(stats.Nodes[a], stats.Nodes[b])
 	})
 
+	if query := u.filterInput.Value(); query != "" {
+		stats.Nodes = filterNodes(stats.Nodes, query)
+		stats.NumNodes = len(stats.Nodes)
+	}
+
 	return stats
 }
 
+// SelectedNode returns the currently highlighted node, or nil if there's
+// nothing to select -- e.g. a live filter query just narrowed the node list
+// to zero matches and u.start/u.end/u.current haven't caught up yet.
 func (u *UIModel) SelectedNode() *Node {
-	return u.Stats().Nodes[u.start:u.end][u.current]
+	nodes := u.Stats().Nodes
+	if u.start < 0 || u.end > len(nodes) || u.start >= u.end {
+		return nil
+	}
+	page := nodes[u.start:u.end]
+	if u.current < 0 || u.current >= len(page) {
+		return nil
+	}
+	return page[u.current]
 }
 
 func (u *UIModel) SelectedNodeName() string {
-	nodeName := u.SelectedNode().Name()
+	return u.nodeCopyName(u.SelectedNode())
+}
+
+// nodeCopyName returns the value that openNode/execNode copies to the
+// clipboard for n -- its name, or its instance ID if u.copyInstanceID is
+// set -- or "" if n is nil.
+func (u *UIModel) nodeCopyName(n *Node) string {
+	if n == nil {
+		return ""
+	}
 	if u.copyInstanceID {
-		nodeName = u.SelectedNode().InstanceID()
+		return n.InstanceID()
 	}
-
-	return nodeName
+	return n.Name()
 }
 
 func (u *UIModel) Keys() KeyMap {
 	enterDesc := "copy node name"
 	if u.copyInstanceID {
 		enterDesc = "copy instance id"
-		if u.nodeExec != "" {
-			enterDesc += " (run NODE_EXEC cmd)"
+		if u.execConfig.Mode != nodeexec.ModeTemplate || u.execConfig.Template != "" {
+			enterDesc += fmt.Sprintf(" (run %s cmd)", u.execConfig.Mode)
 		}
-		u.keys.Enter.SetHelp("enter", enterDesc)
 	}
 
+	if u.viewMode == viewModeDetail {
+		enterDesc = "copy pod name"
+	}
+	u.keys.Enter.SetHelp("enter", enterDesc)
+
+	u.keys.Move.SetEnabled(u.viewMode != viewModeChart)
+	u.keys.Page.SetEnabled(u.viewMode == viewModeList)
+	u.keys.Enter.SetEnabled(u.viewMode != viewModeChart)
+	u.keys.Filter.SetEnabled(u.viewMode == viewModeList)
+	u.keys.Detail.SetEnabled(u.viewMode == viewModeList)
+	u.keys.Chart.SetEnabled(u.viewMode == viewModeList)
+	u.keys.ExecMode.SetEnabled(u.viewMode == viewModeList)
+	u.keys.Back.SetEnabled(u.viewMode != viewModeList)
+	u.keys.NodeCopy.SetEnabled(u.viewMode == viewModeDetail)
+
 	return u.keys
 }
 
-func NewUIModel(extraLabels []string, nodeSort string, style *Style, copyInstanceID bool) *UIModel {
+// NewUIModel constructs a UIModel. execMode selects how openNode opens a
+// node ("", "template", "ssm", "kubectl-debug", or "ssh"); an empty
+// execMode defaults to the legacy NODE_EXEC shell template. debugImage,
+// sshUser, and sshKeyPath configure the kubectl-debug and ssh modes.
+func NewUIModel(extraLabels []string, nodeSort string, style *Style, copyInstanceID bool, execMode, debugImage, sshUser, sshKeyPath string) *UIModel {
 	pager := paginator.New()
 	pager.Type = paginator.Dots
 	pager.ActiveDot = activeDot
 	pager.InactiveDot = inactiveDot
 
-	nodeExec := os.Getenv("NODE_EXEC")
+	mode := nodeexec.Mode(execMode)
+	if mode == "" {
+		mode = nodeexec.ModeTemplate
+	}
+	execConfig := nodeexec.Config{
+		Mode:       mode,
+		Template:   os.Getenv("NODE_EXEC"),
+		DebugImage: debugImage,
+		SSHUser:    sshUser,
+		SSHKeyPath: sshKeyPath,
+	}
+
+	filterInput := textinput.New()
+	filterInput.Prompt = "/"
+	filterInput.Placeholder = "filter nodes"
+
+	podPager := paginator.New()
+	podPager.Type = paginator.Dots
+	podPager.ActiveDot = activeDot
+	podPager.InactiveDot = inactiveDot
+
 	return &UIModel{
 		// red to green
 		progress:       progress.New(style.gradient),
@@ -169,7 +282,10 @@ func NewUIModel(extraLabels []string, nodeSort string, style *Style, copyInstanc
 		start:          0,
 		end:            0,
 		copyInstanceID: copyInstanceID,
-		nodeExec:       nodeExec,
+		execConfig:     execConfig,
+		filterInput:    filterInput,
+		viewMode:       viewModeList,
+		podPaginator:   podPager,
 	}
 }
 
@@ -182,6 +298,13 @@ func (u *UIModel) Init() tea.Cmd {
 }
 
 func (u *UIModel) View() string {
+	if u.viewMode == viewModeDetail && u.detailNode != nil {
+		return u.viewPodDetail() + u.help.View(u.Keys())
+	}
+	if u.viewMode == viewModeChart && u.chartNode != nil {
+		return u.viewChart() + u.help.View(u.Keys())
+	}
+
 	b := strings.Builder{}
 
 	stats := u.Stats()
@@ -195,9 +318,26 @@ func (u *UIModel) View() string {
 	enPrinter.Fprintf(&b, "%d pods (%d pending %d running %d bound)\n", stats.TotalPods,
 		stats.PodsByPhase[v1.PodPending], stats.PodsByPhase[v1.PodRunning], stats.BoundPodCount)
 
+	if u.filtering || u.filterInput.Value() != "" {
+		fmt.Fprintln(&b, u.filterInput.View())
+	}
+
+	if u.execErr != nil {
+		fmt.Fprintf(&b, "exec error: %s\n", u.execErr)
+	}
+
 	if stats.NumNodes == 0 {
+		// Clamp the slice bounds so a stale SelectedNode() call (e.g. from
+		// a keypress queued before this render) doesn't slice an empty
+		// Nodes with leftover non-zero bounds from the last non-empty page.
+		u.start, u.end = 0, 0
+
 		fmt.Fprintln(&b)
-		fmt.Fprintln(&b, "Waiting for update or no nodes found...")
+		if u.filterInput.Value() != "" {
+			fmt.Fprintln(&b, "No nodes match filter...")
+		} else {
+			fmt.Fprintln(&b, "Waiting for update or no nodes found...")
+		}
 		fmt.Fprintln(&b, u.paginator.View())
 
 		return b.String() + u.help.View(u.Keys())
@@ -230,6 +370,141 @@ func (u *UIModel) View() string {
 	return b.String() + u.help.View(u.Keys())
 }
 
+// viewPodDetail renders the pod drill-down pane for u.detailNode, reusing
+// the node list's progress bar and selection styling.
+func (u *UIModel) viewPodDetail() string {
+	b := strings.Builder{}
+	pods := u.detailNode.Pods()
+
+	fmt.Fprintf(&b, "Pods on %s\n\n", u.detailNode.Name())
+
+	if len(pods) == 0 {
+		fmt.Fprintln(&b, "No pods scheduled on this node.")
+		return b.String()
+	}
+
+	perPage := u.height - 6
+	if perPage < 1 {
+		perPage = 1
+	}
+	u.podPaginator.PerPage = perPage
+	u.podPaginator.SetTotalPages(len(pods))
+	if u.podPaginator.Page*u.podPaginator.PerPage > len(pods) {
+		u.podPaginator.Page = u.podPaginator.TotalPages - 1
+	}
+	start, end := u.podPaginator.GetSliceBounds(len(pods))
+	if u.podCurrent >= end-start {
+		u.podCurrent = (end - start) - 1
+	}
+
+	ctw := text.NewColorTabWriter(&b, 0, 8, 1)
+	fmt.Fprintln(ctw, "NAME\tPHASE\tCPU (req/used)\tMEMORY (req/used)\tOWNER\tQOS\tAGE")
+	for i, p := range pods[start:end] {
+		name := deselectedStyle(p.Name)
+		if i == u.podCurrent {
+			name = selectedStyle(p.Name)
+		}
+		fmt.Fprintf(ctw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			name, p.Phase,
+			u.resourcePair(p.Requests, p.Usage, v1.ResourceCPU),
+			u.resourcePair(p.Requests, p.Usage, v1.ResourceMemory),
+			p.Owner, p.QoSClass, duration.HumanDuration(p.Age))
+	}
+	ctw.Flush()
+
+	fmt.Fprintln(&b, u.podPaginator.View())
+	return b.String()
+}
+
+// resourcePair renders a u.progress.ViewAs bar for usage[res] against
+// requests[res] -- the same bar style writeNodeInfo uses for node-level
+// utilization -- followed by the raw request/used quantities. Falls back to
+// "-" for the bar and/or a quantity when it isn't tracked.
+func (u *UIModel) resourcePair(requests, usage v1.ResourceList, res v1.ResourceName) string {
+	req, hasReq := requests[res]
+	reqStr := "-"
+	if hasReq {
+		reqStr = req.String()
+	}
+	used, hasUsed := usage[res]
+	usedStr := "-"
+	if hasUsed {
+		usedStr = used.String()
+	}
+
+	bar := "-"
+	if hasReq && req.AsApproximateFloat64() != 0 {
+		pct := 0.0
+		if hasUsed {
+			pct = used.AsApproximateFloat64() / req.AsApproximateFloat64()
+		}
+		bar = u.progress.ViewAs(pct)
+	}
+
+	return fmt.Sprintf("%s %s/%s", bar, reqStr, usedStr)
+}
+
+// SelectedPod returns the currently highlighted pod in the detail view, or
+// nil if there are no pods to show.
+func (u *UIModel) SelectedPod() *PodInfo {
+	if u.detailNode == nil {
+		return nil
+	}
+	pods := u.detailNode.Pods()
+	start, end := u.podPaginator.GetSliceBounds(len(pods))
+	if start >= end {
+		return nil
+	}
+	pod := pods[start:end][u.podCurrent]
+	return &pod
+}
+
+// viewChart renders a larger, multi-resource utilization chart for
+// u.chartNode using lipgloss-drawn bars, one row per resource.
+func (u *UIModel) viewChart() string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "Utilization history for %s\n\n", u.chartNode.Name())
+
+	resNameLen := 0
+	for _, res := range u.cluster.resources {
+		if len(res) > resNameLen {
+			resNameLen = len(res)
+		}
+	}
+
+	for _, res := range u.cluster.resources {
+		samples := u.sampler.History(u.chartNode.Name()).Samples(res)
+		fmt.Fprintf(&b, "%-*s  %s\n", resNameLen, res, u.renderUtilizationBar(samples))
+	}
+
+	return b.String()
+}
+
+// renderUtilizationBar draws one block per sample, colored by utilization
+// threshold the same way writeClusterSummary colors its percentages, ending
+// with the most recent ratio as a percentage.
+func (u *UIModel) renderUtilizationBar(samples []HistorySample) string {
+	if len(samples) == 0 {
+		return "(no samples yet)"
+	}
+
+	blocks := make([]string, len(samples))
+	for i, s := range samples {
+		pct := s.Ratio * 100
+		switch {
+		case pct > 90:
+			blocks[i] = u.style.green("█")
+		case pct > 60:
+			blocks[i] = u.style.yellow("█")
+		default:
+			blocks[i] = u.style.red("█")
+		}
+	}
+
+	last := samples[len(samples)-1]
+	return fmt.Sprintf("%s %0.1f%%", strings.Join(blocks, ""), last.Ratio*100)
+}
+
 func (u *UIModel) writeNodeInfo(n *Node, w io.Writer, resources []v1.ResourceName, nodeIndex int) {
 	allocatable := n.Allocatable()
 	used := n.Used()
@@ -260,7 +535,7 @@ func (u *UIModel) writeNodeInfo(n *Node, w io.Writer, resources []v1.ResourceNam
 			}
 
 			fmt.Fprintf(w, style)
-			fmt.Fprintf(w, "\t%s\t%s\t(%d pods)\t%s%s", res, u.progress.ViewAs(pct), n.NumPods(), n.InstanceType(), priceLabel)
+			fmt.Fprintf(w, "\t%s\t%s\t%s\t(%d pods)\t%s%s", res, u.progress.ViewAs(pct), Sparkline(u.sampler.History(n.Name()).Samples(res)), n.NumPods(), n.InstanceType(), priceLabel)
 
 			// node compute type
 			if n.IsOnDemand() {
@@ -301,7 +576,7 @@ func (u *UIModel) writeNodeInfo(n *Node, w io.Writer, resources []v1.ResourceNam
 			}
 
 		} else {
-			fmt.Fprintf(w, " \t%s\t%s\t\t\t\t\t", res, u.progress.ViewAs(pct))
+			fmt.Fprintf(w, " \t%s\t%s\t%s\t\t\t\t\t", res, u.progress.ViewAs(pct), Sparkline(u.sampler.History(n.Name()).Samples(res)))
 			for range u.extraLabels {
 				fmt.Fprintf(w, "\t")
 			}
@@ -367,14 +642,119 @@ func tickCmd() tea.Cmd {
 	})
 }
 
+// sampleMsg triggers a Sampler.Sample pass to record node history.
+type sampleMsg time.Time
+
+func sampleTickCmd() tea.Cmd {
+	return tea.Tick(DefaultSampleInterval, func(t time.Time) tea.Msg {
+		return sampleMsg(t)
+	})
+}
+
 func (u *UIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		u.height = msg.Height
 		u.help.Width = msg.Width
-		return u, tickCmd()
+		// Start the sample tick chain only once; every sampleMsg reschedules
+		// itself, so starting another chain per resize would sample more
+		// often than DefaultSampleInterval.
+		if u.sampling {
+			return u, tickCmd()
+		}
+		u.sampling = true
+		return u, tea.Batch(tickCmd(), sampleTickCmd())
 	case tea.KeyMsg:
+		if u.filtering {
+			switch msg.String() {
+			case "esc":
+				u.filtering = false
+				u.filterInput.SetValue("")
+				u.filterInput.Blur()
+				u.current = 0
+			case "enter":
+				u.filtering = false
+				u.filterInput.Blur()
+			default:
+				var cmd tea.Cmd
+				u.filterInput, cmd = u.filterInput.Update(msg)
+				u.current = 0
+				return u, cmd
+			}
+			return u, nil
+		}
+
+		if u.viewMode == viewModeDetail {
+			switch msg.String() {
+			case "esc":
+				u.viewMode = viewModeList
+				u.detailNode = nil
+				u.podCurrent = 0
+				u.podPaginator.Page = 0
+			case "q", "ctrl+c":
+				return u, tea.Quit
+			case "up":
+				if u.podCurrent > 0 {
+					u.podCurrent--
+				} else if u.podPaginator.Page > 0 {
+					u.podPaginator.PrevPage()
+					u.podCurrent = u.podPaginator.PerPage - 1
+				}
+			case "down":
+				pods := u.detailNode.Pods()
+				start, end := u.podPaginator.GetSliceBounds(len(pods))
+				if u.podCurrent < (end-start)-1 {
+					u.podCurrent++
+				} else if u.podPaginator.Page != u.podPaginator.TotalPages-1 {
+					u.podPaginator.NextPage()
+					u.podCurrent = 0
+				}
+			case "enter":
+				if pod := u.SelectedPod(); pod != nil {
+					if err := clipboard.Init(); err != nil {
+						panic(err)
+					}
+					clipboard.Write(clipboard.FmtText, []byte(pod.Name))
+				}
+			case "n":
+				// Drilling into a node's pods shouldn't lose access to the
+				// node-level copy/exec action enter performs in the list --
+				// it's just moved off enter, which now copies the pod name.
+				return u, execNode(u, msg, u.detailNode)
+			}
+			return u, nil
+		}
+
+		if u.viewMode == viewModeChart {
+			switch msg.String() {
+			case "esc":
+				u.viewMode = viewModeList
+				u.chartNode = nil
+			case "q", "ctrl+c":
+				return u, tea.Quit
+			}
+			return u, nil
+		}
+
 		switch msg.String() {
+		case "/":
+			u.filtering = true
+			u.filterInput.Focus()
+			return u, textinput.Blink
+		case "tab":
+			if n := u.SelectedNode(); n != nil {
+				u.detailNode = n
+				u.viewMode = viewModeDetail
+				u.podCurrent = 0
+				u.podPaginator.Page = 0
+			}
+			return u, nil
+		case "g":
+			if n := u.SelectedNode(); n != nil {
+				u.chartNode = n
+				u.viewMode = viewModeChart
+			}
+			return u, nil
 		case "up":
 			if u.current > 0 {
 				u.current--
@@ -395,6 +775,8 @@ func (u *UIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					u.current = 0
 				}
 			}
+		case "e":
+			u.execConfig.Mode = u.execConfig.Mode.Next()
 		case "q", "esc", "ctrl+c":
 			return u, tea.Quit
 		case "enter":
@@ -407,6 +789,12 @@ func (u *UIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case tickMsg:
 		return u, tickCmd()
+	case sampleMsg:
+		// Sample the unfiltered cluster, not u.Stats(): history recording
+		// shouldn't stop for a node just because it's hidden by an active
+		// "/" filter query.
+		u.sampler.Sample(time.Time(msg), u.cluster.Stats())
+		return u, sampleTickCmd()
 	}
 	var cmd tea.Cmd
 	u.paginator, cmd = u.paginator.Update(msg)
@@ -414,12 +802,27 @@ func (u *UIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func openNode(u *UIModel, msg tea.Msg) tea.Cmd {
-	nodeName := u.SelectedNodeName()
-	if u.nodeExec == "" || nodeName == "" || u.SelectedNode().IsFargate() {
+	return execNode(u, msg, u.SelectedNode())
+}
+
+// execNode runs u.execConfig against n (an ssm session, kubectl debug,
+// ssh, etc.), or just copies n's name/instance ID to the clipboard if
+// exec isn't applicable -- no command configured, n is a Fargate node,
+// or u.execConfig.Command returned an error. It's shared by the list
+// view's enter binding and the pod detail view's "n" binding, since
+// both need to act on a node rather than the pod enter copies there.
+func execNode(u *UIModel, msg tea.Msg, selected *Node) tea.Cmd {
+	if selected == nil {
+		return nil
+	}
+	nodeName := u.nodeCopyName(selected)
+
+	c, err := u.execConfig.Command(selected.Name(), selected.InstanceID())
+	u.execErr = err
+	if err != nil || c == nil || nodeName == "" || selected.IsFargate() {
 		// copy only actions
-		err := clipboard.Init()
-		if err != nil {
-			panic(err)
+		if cerr := clipboard.Init(); cerr != nil {
+			panic(cerr)
 		}
 
 		clipboard.Write(clipboard.FmtText, []byte(nodeName))
@@ -429,8 +832,6 @@ func openNode(u *UIModel, msg tea.Msg) tea.Cmd {
 		return cmd
 	}
 
-	nodeExecCmd := fmt.Sprintf(u.nodeExec, nodeName)
-	c := exec.Command("/bin/sh", "-c", nodeExecCmd)
 	return tea.ExecProcess(c, func(err error) tea.Msg {
 		return execFinishedMsg{err}
 	})
@@ -477,3 +878,103 @@ func makeNodeSorter(nodeSort string) func(lhs *Node, rhs *Node) bool {
 		return sortOrder(natsort.Compare(lhsLabel, rhsLabel))
 	}
 }
+
+// filterNodes returns the subset of nodes whose name, instance type, instance
+// ID, or any label value set on the node (not only the extra labels shown as
+// columns) fuzzy-matches query, ordered by descending match score. Computed
+// label values (see ComputeLabel) are not matched. Nodes that don't match at
+// all are dropped.
+func filterNodes(nodes []*Node, query string) []*Node {
+	type scoredNode struct {
+		node  *Node
+		score int
+	}
+
+	scored := make([]scoredNode, 0, len(nodes))
+	for _, n := range nodes {
+		matched, best := false, 0
+		for _, candidate := range nodeFilterCandidates(n) {
+			score, ok := fuzzyScore(query, candidate)
+			if !ok {
+				continue
+			}
+			matched = true
+			if score > best {
+				best = score
+			}
+		}
+		if matched {
+			scored = append(scored, scoredNode{node: n, score: best})
+		}
+	}
+
+	sort.SliceStable(scored, func(a, b int) bool {
+		return scored[a].score > scored[b].score
+	})
+
+	filtered := make([]*Node, 0, len(scored))
+	for _, s := range scored {
+		filtered = append(filtered, s.node)
+	}
+	return filtered
+}
+
+// nodeFilterCandidates returns the strings a filter query is matched against for n.
+func nodeFilterCandidates(n *Node) []string {
+	candidates := []string{n.Name(), n.InstanceType(), n.InstanceID()}
+	for _, v := range n.node.Labels {
+		candidates = append(candidates, v)
+	}
+	return candidates
+}
+
+// fuzzyScore reports whether query fuzzy-matches target as an ordered
+// subsequence, and if so a score that rewards consecutive runs and matches
+// that start on a word boundary, similar to fzf's bonus-for-consecutive and
+// bonus-for-boundary heuristics. Substring matches always count and receive
+// an additional flat bonus.
+func fuzzyScore(query, target string) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+
+	lowerQuery, lowerTarget := strings.ToLower(query), strings.ToLower(target)
+
+	score, matched := scoreSubsequence(lowerQuery, lowerTarget)
+	if !matched {
+		return 0, false
+	}
+	if strings.Contains(lowerTarget, lowerQuery) {
+		score += 100
+	}
+	return score, true
+}
+
+// scoreSubsequence scores an in-order subsequence match of query against
+// target, rewarding consecutive runs and word-boundary starts.
+func scoreSubsequence(query, target string) (int, bool) {
+	score, consecutive, qi := 0, 0, 0
+	for ti := 0; ti < len(target) && qi < len(query); ti++ {
+		if target[ti] != query[qi] {
+			consecutive = 0
+			continue
+		}
+
+		points := 1
+		if consecutive > 0 {
+			points += 5
+		}
+		if ti == 0 || isWordBoundary(target[ti-1]) {
+			points += 10
+		}
+		score += points
+		consecutive++
+		qi++
+	}
+
+	return score, qi == len(query)
+}
+
+func isWordBoundary(b byte) bool {
+	return b == '-' || b == '_' || b == '.' || b == '/'
+}
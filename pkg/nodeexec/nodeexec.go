@@ -0,0 +1,127 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package nodeexec builds the command used to open a session on a node,
+// supporting a NODE_EXEC shell template as well as first-class SSM,
+// kubectl debug, and SSH access modes.
+package nodeexec
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// Mode selects how openNode opens a session on a node.
+type Mode string
+
+const (
+	// ModeTemplate runs Config.Template through /bin/sh -c, as the
+	// original NODE_EXEC environment variable has always done.
+	ModeTemplate Mode = "template"
+	// ModeSSM opens an AWS SSM Session Manager session to the node's
+	// instance.
+	ModeSSM Mode = "ssm"
+	// ModeKubectlDebug runs `kubectl debug node/<name>`.
+	ModeKubectlDebug Mode = "kubectl-debug"
+	// ModeSSH opens a plain SSH session to the node.
+	ModeSSH Mode = "ssh"
+)
+
+// Modes lists the supported modes in the order the "e" key cycles through
+// them.
+var Modes = []Mode{ModeTemplate, ModeSSM, ModeKubectlDebug, ModeSSH}
+
+// Next returns the mode that follows m in Modes, wrapping around to the
+// first mode. An unrecognized m cycles to the first mode.
+func (m Mode) Next() Mode {
+	for i, mode := range Modes {
+		if mode == m {
+			return Modes[(i+1)%len(Modes)]
+		}
+	}
+	return Modes[0]
+}
+
+// String returns a short label for the mode, suitable for help text.
+func (m Mode) String() string {
+	switch m {
+	case ModeSSM:
+		return "ssm"
+	case ModeKubectlDebug:
+		return "kubectl-debug"
+	case ModeSSH:
+		return "ssh"
+	default:
+		return "template"
+	}
+}
+
+// DefaultDebugImage is used by ModeKubectlDebug when Config.DebugImage is
+// unset.
+const DefaultDebugImage = "busybox"
+
+// Config holds the settings needed to build the exec.Cmd for each Mode.
+type Config struct {
+	Mode Mode
+
+	// Template is the NODE_EXEC sprintf template used by ModeTemplate,
+	// e.g. "kubectl node-shell %s".
+	Template string
+	// DebugImage is the container image used by ModeKubectlDebug.
+	DebugImage string
+	// SSHUser and SSHKeyPath configure ModeSSH.
+	SSHUser    string
+	SSHKeyPath string
+}
+
+// Command builds the exec.Cmd that opens a session on the node identified
+// by nodeName/instanceID under cfg.Mode. It returns a nil Cmd (and nil
+// error) when the mode has nothing to run, e.g. ModeTemplate with no
+// template configured.
+func (cfg Config) Command(nodeName, instanceID string) (*exec.Cmd, error) {
+	switch cfg.Mode {
+	case ModeSSM:
+		if instanceID == "" {
+			return nil, fmt.Errorf("ssm exec requires an instance id for node %q", nodeName)
+		}
+		return exec.Command("aws", "ssm", "start-session", "--target", instanceID), nil
+
+	case ModeKubectlDebug:
+		image := cfg.DebugImage
+		if image == "" {
+			image = DefaultDebugImage
+		}
+		return exec.Command("kubectl", "debug", fmt.Sprintf("node/%s", nodeName), "-it", "--image="+image), nil
+
+	case ModeSSH:
+		if cfg.SSHUser == "" {
+			return nil, fmt.Errorf("ssh exec requires an ssh user")
+		}
+		var args []string
+		if cfg.SSHKeyPath != "" {
+			args = append(args, "-i", cfg.SSHKeyPath)
+		}
+		args = append(args, fmt.Sprintf("%s@%s", cfg.SSHUser, nodeName))
+		return exec.Command("ssh", args...), nil
+
+	case ModeTemplate, "":
+		if cfg.Template == "" {
+			return nil, nil
+		}
+		return exec.Command("/bin/sh", "-c", fmt.Sprintf(cfg.Template, nodeName)), nil
+
+	default:
+		return nil, fmt.Errorf("unknown node-exec mode %q", cfg.Mode)
+	}
+}
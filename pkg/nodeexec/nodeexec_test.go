@@ -0,0 +1,122 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeexec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommandSSM(t *testing.T) {
+	cfg := Config{Mode: ModeSSM}
+	cmd, err := cfg.Command("ip-10-0-1-23.ec2.internal", "i-0123456789abcdef0")
+	if err != nil {
+		t.Fatalf("Command: %v", err)
+	}
+	want := []string{"aws", "ssm", "start-session", "--target", "i-0123456789abcdef0"}
+	if strings.Join(cmd.Args, " ") != strings.Join(want, " ") {
+		t.Errorf("args = %v, want %v", cmd.Args, want)
+	}
+}
+
+func TestCommandSSMRequiresInstanceID(t *testing.T) {
+	cfg := Config{Mode: ModeSSM}
+	if _, err := cfg.Command("ip-10-0-1-23.ec2.internal", ""); err == nil {
+		t.Fatal("expected error for missing instance id")
+	}
+}
+
+func TestCommandKubectlDebugDefaultImage(t *testing.T) {
+	cfg := Config{Mode: ModeKubectlDebug}
+	cmd, err := cfg.Command("ip-10-0-1-23.ec2.internal", "")
+	if err != nil {
+		t.Fatalf("Command: %v", err)
+	}
+	want := []string{"kubectl", "debug", "node/ip-10-0-1-23.ec2.internal", "-it", "--image=" + DefaultDebugImage}
+	if strings.Join(cmd.Args, " ") != strings.Join(want, " ") {
+		t.Errorf("args = %v, want %v", cmd.Args, want)
+	}
+}
+
+func TestCommandKubectlDebugConfiguredImage(t *testing.T) {
+	cfg := Config{Mode: ModeKubectlDebug, DebugImage: "alpine:3.19"}
+	cmd, err := cfg.Command("ip-10-0-1-23.ec2.internal", "")
+	if err != nil {
+		t.Fatalf("Command: %v", err)
+	}
+	if cmd.Args[len(cmd.Args)-1] != "--image=alpine:3.19" {
+		t.Errorf("last arg = %q, want --image=alpine:3.19", cmd.Args[len(cmd.Args)-1])
+	}
+}
+
+func TestCommandSSH(t *testing.T) {
+	cfg := Config{Mode: ModeSSH, SSHUser: "ec2-user", SSHKeyPath: "/home/me/.ssh/id_rsa"}
+	cmd, err := cfg.Command("ip-10-0-1-23.ec2.internal", "")
+	if err != nil {
+		t.Fatalf("Command: %v", err)
+	}
+	want := []string{"ssh", "-i", "/home/me/.ssh/id_rsa", "ec2-user@ip-10-0-1-23.ec2.internal"}
+	if strings.Join(cmd.Args, " ") != strings.Join(want, " ") {
+		t.Errorf("args = %v, want %v", cmd.Args, want)
+	}
+}
+
+func TestCommandSSHRequiresUser(t *testing.T) {
+	cfg := Config{Mode: ModeSSH}
+	if _, err := cfg.Command("ip-10-0-1-23.ec2.internal", ""); err == nil {
+		t.Fatal("expected error for missing ssh user")
+	}
+}
+
+func TestCommandTemplate(t *testing.T) {
+	cfg := Config{Mode: ModeTemplate, Template: "kubectl node-shell %s"}
+	cmd, err := cfg.Command("ip-10-0-1-23.ec2.internal", "")
+	if err != nil {
+		t.Fatalf("Command: %v", err)
+	}
+	want := []string{"/bin/sh", "-c", "kubectl node-shell ip-10-0-1-23.ec2.internal"}
+	if strings.Join(cmd.Args, " ") != strings.Join(want, " ") {
+		t.Errorf("args = %v, want %v", cmd.Args, want)
+	}
+}
+
+func TestCommandTemplateEmptyIsNoop(t *testing.T) {
+	cfg := Config{Mode: ModeTemplate}
+	cmd, err := cfg.Command("ip-10-0-1-23.ec2.internal", "")
+	if err != nil || cmd != nil {
+		t.Fatalf("Command() = %v, %v, want nil, nil", cmd, err)
+	}
+}
+
+func TestCommandUnknownMode(t *testing.T) {
+	cfg := Config{Mode: Mode("bogus")}
+	if _, err := cfg.Command("ip-10-0-1-23.ec2.internal", ""); err == nil {
+		t.Fatal("expected error for unknown mode")
+	}
+}
+
+func TestModeNextCyclesAndWraps(t *testing.T) {
+	m := ModeTemplate
+	for range Modes {
+		next := m.Next()
+		if next == m {
+			t.Fatalf("Next() returned the same mode %q", m)
+		}
+		m = next
+	}
+	if m != ModeTemplate {
+		t.Errorf("after cycling through all modes, got %q, want to be back at %q", m, ModeTemplate)
+	}
+}